@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Constraint represents a semver range expression such as ">=1.8.0 <2.0.0 || >=2.1.0", "~1.8", or
+// "^1.9.5". A version satisfies the constraint if it matches at least one of the "||"-separated
+// groups, where each group is itself a space-separated (AND) list of comparators.
+type Constraint struct {
+	raw    string
+	groups [][]comparator
+}
+
+// comparator pairs a relational operator with the version it compares against.
+type comparator struct {
+	op  string
+	ver *semver.Version
+}
+
+// partialVersion is a version expression with optionally-omitted minor/patch components, as found in
+// tilde, caret, wildcard (1.2.x) and hyphen-range terms.
+type partialVersion struct {
+	major int64
+	minor *int64
+	patch *int64
+}
+
+// ParseConstraint parses a semver range expression into a Constraint.
+func ParseConstraint(expr string) (*Constraint, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("constraint expression is empty")
+	}
+
+	c := &Constraint{raw: expr}
+	for _, orPart := range strings.Split(expr, "||") {
+		group, err := parseAndGroup(strings.TrimSpace(orPart))
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q: %v", expr, err)
+		}
+		c.groups = append(c.groups, group)
+	}
+
+	return c, nil
+}
+
+// Check returns whether v satisfies the constraint.
+func (c *Constraint) Check(v *semver.Version) bool {
+	for _, group := range c.groups {
+		if matchesAll(v, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+func matchesAll(v *semver.Version, comparators []comparator) bool {
+	for _, cmp := range comparators {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cmp comparator) matches(v *semver.Version) bool {
+	switch result := compareVersions(v, cmp.ver); cmp.op {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	default:
+		return false
+	}
+}
+
+// compareVersions returns -1, 0, or 1 depending on whether a is less than, equal to, or greater than
+// b by major.minor.patch. Pre-release and build metadata are not considered: callers filter out
+// pre-release versions before matching against a Constraint.
+func compareVersions(a, b *semver.Version) int {
+	if a.Major != b.Major {
+		if a.Major < b.Major {
+			return -1
+		}
+		return 1
+	}
+	if a.Minor != b.Minor {
+		if a.Minor < b.Minor {
+			return -1
+		}
+		return 1
+	}
+	if a.Patch != b.Patch {
+		if a.Patch < b.Patch {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// parseAndGroup parses a space-separated list of comparators, tilde/caret/wildcard terms, and
+// hyphen ranges (e.g. "1.2 - 1.5") into the list of comparators they expand to.
+func parseAndGroup(s string) ([]comparator, error) {
+	tokens := strings.Fields(s)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty constraint group")
+	}
+
+	var comparators []comparator
+	for i := 0; i < len(tokens); i++ {
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			cmps, err := hyphenRange(tokens[i], tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, cmps...)
+			i += 2
+			continue
+		}
+
+		cmps, err := parseTerm(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, cmps...)
+	}
+
+	return comparators, nil
+}
+
+var comparatorOperators = []string{"<=", ">=", "!=", "<", ">", "="}
+
+// parseTerm parses a single constraint token: an explicit comparator ("<=1.2.3"), a tilde or caret
+// range ("~1.8", "^1.9.5"), or a bare (possibly wildcarded) version ("1.2", "1.2.x").
+func parseTerm(token string) ([]comparator, error) {
+	for _, op := range comparatorOperators {
+		if strings.HasPrefix(token, op) {
+			pv, err := parsePartialVersion(token[len(op):])
+			if err != nil {
+				return nil, err
+			}
+			return []comparator{{op, pv.floor()}}, nil
+		}
+	}
+
+	if strings.HasPrefix(token, "~") {
+		pv, err := parsePartialVersion(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return tildeRange(pv), nil
+	}
+
+	if strings.HasPrefix(token, "^") {
+		pv, err := parsePartialVersion(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return caretRange(pv), nil
+	}
+
+	pv, err := parsePartialVersion(token)
+	if err != nil {
+		return nil, err
+	}
+	return wildcardRange(pv), nil
+}
+
+// parsePartialVersion parses a (possibly incomplete or wildcarded) version string such as "1", "1.2",
+// "1.2.3", or "1.2.x" into its components. A missing or "x"/"X"/"*" component is left nil.
+func parsePartialVersion(s string) (*partialVersion, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "v")
+	if s == "" {
+		return nil, fmt.Errorf("empty version term")
+	}
+
+	// Pre-release and build metadata are not meaningful on a constraint term; drop them.
+	if idx := strings.IndexAny(s, "-+"); idx >= 0 {
+		s = s[:idx]
+	}
+
+	segments := strings.Split(s, ".")
+	if len(segments) > 3 {
+		return nil, fmt.Errorf("invalid version term %q", s)
+	}
+
+	values := make([]*int64, 3)
+	for i, seg := range segments {
+		if seg == "x" || seg == "X" || seg == "*" {
+			continue
+		}
+		n, err := strconv.ParseInt(seg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version segment %q in %q", seg, s)
+		}
+		values[i] = &n
+	}
+
+	if values[0] == nil {
+		return nil, fmt.Errorf("version term %q is missing a major component", s)
+	}
+
+	return &partialVersion{major: *values[0], minor: values[1], patch: values[2]}, nil
+}
+
+// floor returns the version formed by treating any omitted component as zero.
+func (pv *partialVersion) floor() *semver.Version {
+	return &semver.Version{Major: pv.major, Minor: derefOr(pv.minor, 0), Patch: derefOr(pv.patch, 0)}
+}
+
+func derefOr(p *int64, def int64) int64 {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// tildeRange expands a "~1.8.2"-style term: allow patch-level changes if a minor is specified, or
+// minor-level changes if not.
+func tildeRange(pv *partialVersion) []comparator {
+	lower := pv.floor()
+
+	var upper *semver.Version
+	if pv.minor == nil {
+		upper = &semver.Version{Major: pv.major + 1}
+	} else {
+		upper = &semver.Version{Major: pv.major, Minor: *pv.minor + 1}
+	}
+
+	return []comparator{{">=", lower}, {"<", upper}}
+}
+
+// caretRange expands a "^1.9.5"-style term: allow changes that do not modify the left-most non-zero
+// component.
+func caretRange(pv *partialVersion) []comparator {
+	lower := pv.floor()
+
+	var upper *semver.Version
+	switch {
+	case pv.major > 0 || pv.minor == nil:
+		upper = &semver.Version{Major: pv.major + 1}
+	case *pv.minor > 0 || pv.patch == nil:
+		upper = &semver.Version{Major: pv.major, Minor: *pv.minor + 1}
+	default:
+		upper = &semver.Version{Major: pv.major, Minor: *pv.minor, Patch: *pv.patch + 1}
+	}
+
+	return []comparator{{">=", lower}, {"<", upper}}
+}
+
+// wildcardRange expands a bare term with no operator, such as "1.2" or "1.2.x", into the range it
+// covers. A fully-specified version ("1.2.3") becomes an exact match.
+func wildcardRange(pv *partialVersion) []comparator {
+	lower := pv.floor()
+
+	var upper *semver.Version
+	switch {
+	case pv.minor == nil:
+		upper = &semver.Version{Major: pv.major + 1}
+	case pv.patch == nil:
+		upper = &semver.Version{Major: pv.major, Minor: *pv.minor + 1}
+	default:
+		return []comparator{{"=", lower}}
+	}
+
+	return []comparator{{">=", lower}, {"<", upper}}
+}
+
+// hyphenRange expands a "1.2 - 1.5"-style range into an inclusive lower bound and an upper bound that
+// is inclusive only when the high end is a fully-specified version.
+func hyphenRange(lowToken, highToken string) ([]comparator, error) {
+	lowPV, err := parsePartialVersion(lowToken)
+	if err != nil {
+		return nil, err
+	}
+	highPV, err := parsePartialVersion(highToken)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := lowPV.floor()
+
+	var upperOp string
+	var upper *semver.Version
+	switch {
+	case highPV.minor == nil:
+		upperOp, upper = "<", &semver.Version{Major: highPV.major + 1}
+	case highPV.patch == nil:
+		upperOp, upper = "<", &semver.Version{Major: highPV.major, Minor: *highPV.minor + 1}
+	default:
+		upperOp, upper = "<=", highPV.floor()
+	}
+
+	return []comparator{{">=", lower}, {upperOp, upper}}, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		version  string
+		expected bool
+	}{
+		{">=1.8.0 <2.0.0", "1.9.9", true},
+		{">=1.8.0 <2.0.0", "2.0.0", false},
+		{">=1.8.0 <2.0.0 || >=2.1.0", "2.1.0", true},
+		{">=1.8.0 <2.0.0 || >=2.1.0", "2.0.5", false},
+		{"~1.8", "1.8.9", true},
+		{"~1.8", "1.9.0", false},
+		{"~1.8.2", "1.8.1", false},
+		{"~1.8.2", "1.8.5", true},
+		{"^1.9.5", "1.20.0", true},
+		{"^1.9.5", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"1.2.x", "1.2.7", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.2 - 1.5", "1.2.0", true},
+		{"1.2 - 1.5", "1.5.9", true},
+		{"1.2 - 1.5", "1.6.0", false},
+		{"1.2.0 - 1.5.0", "1.5.1", false},
+		{"=1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{"!=1.2.3", "1.2.4", true},
+	}
+
+	for _, tc := range testCases {
+		constraint, err := ParseConstraint(tc.expr)
+		if err != nil {
+			t.Errorf("failed to parse constraint %q: %v", tc.expr, err)
+			continue
+		}
+
+		got := constraint.Check(semver.New(tc.version))
+		if got != tc.expected {
+			t.Errorf("constraint %q checking %s: got %v, expected %v", tc.expr, tc.version, got, tc.expected)
+		}
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	invalidExprs := []string{
+		"",
+		"   ",
+		">=",
+		"1.2.3.4",
+		"not-a-version",
+	}
+
+	for _, expr := range invalidExprs {
+		if _, err := ParseConstraint(expr); err == nil {
+			t.Errorf("expected error parsing constraint %q, got none", expr)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+// etagCacheDir holds one JSON file per cached (owner/repo, page) response, keyed by ETag, so repeat
+// runs can issue conditional requests instead of counting fully against the rate limit.
+const etagCacheDir = ".release-cache"
+
+const maxFetchRetries = 5
+const baseRetryDelay = 1 * time.Second
+
+// newGithubClient builds a github.Client authenticated with GITHUB_TOKEN or GITHUB_PAT, if either is
+// set in the environment. An authenticated client raises the rate limit from 60/hr to 5000/hr; with
+// neither variable set, an unauthenticated client is returned as before.
+func newGithubClient(ctx context.Context) *github.Client {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_PAT")
+	}
+	if token == "" {
+		return github.NewClient(nil)
+	}
+
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return github.NewClient(oauth2.NewClient(ctx, tokenSource))
+}
+
+// etagCacheEntry is the on-disk representation of a cached page of releases, along with the
+// pagination and ETag information needed to serve it again on a 304 Not Modified.
+type etagCacheEntry struct {
+	ETag     string                      `json:"etag"`
+	NextPage int                         `json:"next_page"`
+	Rate     github.Rate                 `json:"rate"`
+	Releases []*github.RepositoryRelease `json:"releases"`
+}
+
+func etagCacheKey(owner, repo string, page int) string {
+	return fmt.Sprintf("%s/%s?page=%d", owner, repo, page)
+}
+
+func etagCachePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(etagCacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadEtagCacheEntry returns the cached entry for key, or nil if there is none. A corrupt or
+// unreadable entry is treated the same as a missing one: the caller falls back to an unconditional
+// request.
+func loadEtagCacheEntry(key string) *etagCacheEntry {
+	data, err := os.ReadFile(etagCachePath(key))
+	if err != nil {
+		return nil
+	}
+
+	var entry etagCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+
+	return &entry
+}
+
+func saveEtagCacheEntry(key string, entry *etagCacheEntry) error {
+	if err := os.MkdirAll(etagCacheDir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(etagCachePath(key), data, 0o644)
+}
+
+// getReleasesForRepoFromGithub returns a full list (all pages) of releases for a particular owner/repo.
+// Each page is fetched as a conditional request against the on-disk ETag cache, and transient failures
+// (5xx responses, rate-limit and abuse-rate-limit errors) are retried with exponential backoff.
+func getReleasesForRepoFromGithub(ctx context.Context, client *github.Client, repoInput *Input) ([]*github.RepositoryRelease,
+	*github.Rate, error) {
+	page := 1
+	releases := make([]*github.RepositoryRelease, 0)
+	var lastRate *github.Rate
+
+	for page > 0 {
+		releasesPerPage, rate, nextPage, err := fetchReleasesPage(ctx, client, repoInput, page)
+		if err != nil {
+			return releases, rate, err
+		}
+
+		releases = append(releases, releasesPerPage...)
+		lastRate = rate
+		page = nextPage
+	}
+
+	return releases, lastRate, nil
+}
+
+// fetchReleasesPage fetches a single page of releases, retrying transient failures with exponential
+// backoff, and returns the releases, the rate-limit state, and the next page number (0 if this is the
+// last page).
+func fetchReleasesPage(ctx context.Context, client *github.Client, repoInput *Input, page int) ([]*github.RepositoryRelease,
+	*github.Rate, int, error) {
+	key := etagCacheKey(repoInput.Owner, repoInput.Repo, page)
+	cached := loadEtagCacheEntry(key)
+
+	url := fmt.Sprintf("repos/%s/%s/releases?page=%d&per_page=%d", repoInput.Owner, repoInput.Repo, page, RELEASES_PER_PAGE)
+
+	var lastErr error
+	for attempt := 0; attempt < maxFetchRetries; attempt++ {
+		req, err := client.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if cached != nil {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		var releasesPerPage []*github.RepositoryRelease
+		resp, err := client.Do(ctx, req, &releasesPerPage)
+
+		if resp != nil && resp.StatusCode == http.StatusNotModified && cached != nil {
+			return cached.Releases, &cached.Rate, cached.NextPage, nil
+		}
+
+		if err == nil {
+			rate := resp.Rate
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				_ = saveEtagCacheEntry(key, &etagCacheEntry{
+					ETag:     etag,
+					NextPage: resp.NextPage,
+					Rate:     rate,
+					Releases: releasesPerPage,
+				})
+			}
+			return releasesPerPage, &rate, resp.NextPage, nil
+		}
+
+		delay, retryable := retryDelay(err, attempt)
+		if !retryable {
+			var rate *github.Rate
+			if resp != nil {
+				rate = &resp.Rate
+			}
+			return nil, rate, 0, err
+		}
+
+		lastErr = err
+		time.Sleep(delay)
+	}
+
+	return nil, nil, 0, fmt.Errorf("exhausted retries fetching %s: %w", url, lastErr)
+}
+
+// retryDelay inspects err to decide whether the request that produced it should be retried and, if
+// so, how long to wait first. Rate-limit errors wait until GitHub reports the limit will reset or
+// lift; transient 5xx responses back off exponentially.
+func retryDelay(err error, attempt int) (time.Duration, bool) {
+	switch e := err.(type) {
+	case *github.RateLimitError:
+		return time.Until(e.Rate.Reset.Time), true
+	case *github.AbuseRateLimitError:
+		if e.RetryAfter != nil {
+			return *e.RetryAfter, true
+		}
+		return exponentialBackoff(attempt), true
+	}
+
+	if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response != nil && ghErr.Response.StatusCode >= 500 {
+		return exponentialBackoff(attempt), true
+	}
+
+	return 0, false
+}
+
+func exponentialBackoff(attempt int) time.Duration {
+	return baseRetryDelay * time.Duration(math.Pow(2, float64(attempt)))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestEtagCacheKeyAndPath(t *testing.T) {
+	keyA := etagCacheKey("coreos", "etcd", 1)
+	keyB := etagCacheKey("coreos", "etcd", 2)
+	if keyA == keyB {
+		t.Errorf("expected different cache keys for different pages, got %q for both", keyA)
+	}
+
+	pathA := etagCachePath(keyA)
+	pathAAgain := etagCachePath(keyA)
+	if pathA != pathAAgain {
+		t.Errorf("etagCachePath is not deterministic: %q != %q", pathA, pathAAgain)
+	}
+
+	pathB := etagCachePath(keyB)
+	if pathA == pathB {
+		t.Errorf("expected different cache paths for different keys, got %q for both", pathA)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	resetAt := time.Now().Add(time.Minute)
+
+	testCases := []struct {
+		name          string
+		err           error
+		attempt       int
+		wantRetryable bool
+	}{
+		{
+			name:          "rate limit error waits until reset",
+			err:           &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: resetAt}}},
+			attempt:       0,
+			wantRetryable: true,
+		},
+		{
+			name:          "abuse rate limit error with explicit retry-after",
+			err:           &github.AbuseRateLimitError{RetryAfter: durationPtr(30 * time.Second)},
+			attempt:       0,
+			wantRetryable: true,
+		},
+		{
+			name:          "abuse rate limit error without retry-after backs off exponentially",
+			err:           &github.AbuseRateLimitError{},
+			attempt:       2,
+			wantRetryable: true,
+		},
+		{
+			name: "5xx error response is retryable",
+			err: &github.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusBadGateway},
+			},
+			attempt:       1,
+			wantRetryable: true,
+		},
+		{
+			name: "4xx error response is not retryable",
+			err: &github.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			},
+			attempt:       0,
+			wantRetryable: false,
+		},
+		{
+			name:          "plain error is not retryable",
+			err:           errors.New("boom"),
+			attempt:       0,
+			wantRetryable: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, retryable := retryDelay(tc.err, tc.attempt)
+			if retryable != tc.wantRetryable {
+				t.Fatalf("retryDelay(%v, %d) retryable = %v, want %v", tc.err, tc.attempt, retryable, tc.wantRetryable)
+			}
+			if retryable && delay <= 0 {
+				t.Errorf("retryDelay(%v, %d) returned a non-positive delay %v for a retryable error", tc.err, tc.attempt, delay)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	prev := time.Duration(0)
+	for attempt := 0; attempt < 5; attempt++ {
+		delay := exponentialBackoff(attempt)
+		if delay <= prev {
+			t.Errorf("exponentialBackoff(%d) = %v, expected an increase over the previous attempt's %v", attempt, delay, prev)
+		}
+		prev = delay
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
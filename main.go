@@ -3,7 +3,9 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
@@ -11,14 +13,11 @@ import (
 	"strings"
 
 	"github.com/coreos/go-semver/semver"
-	"github.com/google/go-github/github"
 )
 
 // RELEASES_PER_PAGE is a constant to set the # of releases to GET per page. Higher means fewer API calls.
 const RELEASES_PER_PAGE = 100
 
-const GITHUB_RATE_LIMIT_PER_HR = 60
-
 //--- custom sorting ---
 
 type ByMajorMinorPatch []*semver.Version
@@ -58,24 +57,10 @@ func (v ByMajorMinorPatch) Less(i, j int) bool {
 
 //--- end of custom sorting ---
 
-// greaterThanMin returns whether a version (first param) is greater than the min version (second param)
-func greaterThanMin(v *semver.Version, minVersion *semver.Version) bool {
-	if v.Major > minVersion.Major {
-		return true
-	} else if v.Major == minVersion.Major {
-		if v.Minor > minVersion.Minor {
-			return true
-		} else if v.Minor == minVersion.Minor {
-			if v.Patch >= minVersion.Patch {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-// LatestVersions returns a sorted slice with the highest version as its first element and the highest version of the smaller minor versions in a descending order
-func LatestVersions(releases []*semver.Version, minVersion *semver.Version) []*semver.Version {
+// LatestVersions returns a sorted slice with the highest version as its first element, containing the
+// highest patch release for each (major, minor) pair among the non-prerelease versions that satisfy
+// constraint, in descending order.
+func LatestVersions(releases []*semver.Version, constraint *Constraint) []*semver.Version {
 	sort.Sort(ByMajorMinorPatch(releases))
 	versionSlice := make([]*semver.Version, 0)
 	var lastVersion *semver.Version
@@ -89,18 +74,17 @@ func LatestVersions(releases []*semver.Version, minVersion *semver.Version) []*s
 			continue
 		}
 
-		if greaterThanMin(v, minVersion) {
-			if len(versionSlice) > 0 {
-				if v.Major == lastVersion.Major && v.Minor == lastVersion.Minor {
-					// This means that 'v' is a smaller minor version than the last one that was appended
-					continue
-				}
-			}
-			versionSlice = append(versionSlice, v)
-			lastVersion = v
-		} else {
-			break
+		if !constraint.Check(v) {
+			continue
 		}
+
+		if len(versionSlice) > 0 && v.Major == lastVersion.Major && v.Minor == lastVersion.Minor {
+			// This means that 'v' is a smaller minor version than the last one that was appended
+			continue
+		}
+
+		versionSlice = append(versionSlice, v)
+		lastVersion = v
 	}
 
 	return versionSlice
@@ -109,7 +93,7 @@ func LatestVersions(releases []*semver.Version, minVersion *semver.Version) []*s
 type Input struct {
 	Owner      string
 	Repo       string
-	MinVersion *semver.Version
+	Constraint *Constraint
 }
 
 // readInputFromFile opens a file 'input' in the project root and returns a built []Input
@@ -136,14 +120,14 @@ func readInputFromFile(path string) ([]Input, error) {
 		s2 := strings.Split(s1[1], ",")
 		i.Repo = s2[0]
 
-		minVersionString := s2[1]
+		constraintString := s2[1]
 
-		// Defend against invalid min version strings because they will cause semver to panic
-		if validVersionString(minVersionString) {
-			i.MinVersion = semver.New(minVersionString)
-		}else {
-			return nil, fmt.Errorf("minVersion %s for %s/%s is not valid", minVersionString, i.Owner, i.Repo)
+		constraint, err := ParseConstraint(constraintString)
+		if err != nil {
+			return nil, fmt.Errorf("min_version constraint %q for %s/%s is not valid: %v", constraintString,
+				i.Owner, i.Repo, err)
 		}
+		i.Constraint = constraint
 
 		repos = append(repos, i)
 	}
@@ -152,99 +136,95 @@ func readInputFromFile(path string) ([]Input, error) {
 		return nil, err
 	}
 
-	// Github rate limit is 60. We cannot predict the # of API calls because there may be multiple pages of releases
-	// for each repository. However, if the number of repositories exceeds 60, we can guarantee there will be more than
-	// 60 API calls and therefore we can prevent an error
-	if len(repos) > GITHUB_RATE_LIMIT_PER_HR {
-		return nil, fmt.Errorf("number of repositories cannot exceed %d", GITHUB_RATE_LIMIT_PER_HR)
-	}
-
 	return repos, nil
 }
 
-// getReleasesForRepoFromGithub returns a full list (all pages) of releases for a particular owner/repo
-func getReleasesForRepoFromGithub(client *github.Client, repoInput *Input) ([]*github.RepositoryRelease, *github.Rate,
-	error) {
-	ctx := context.Background()
-	opt := &github.ListOptions{Page: 1, PerPage: RELEASES_PER_PAGE}
+// tagRe matches a version string (with any leading "v" already stripped): a required major.minor, an
+// optional patch, an optional pre-release, and optional "+build" metadata. This accepts real-world
+// conventions such as a missing patch ("1.2") and "+incompatible"/"+build.42" metadata, which a
+// strict major.minor.patch regex would reject.
+var tagRe = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(-[0-9A-Za-z][0-9A-Za-z.-]*)?(\+[0-9A-Za-z][0-9A-Za-z.-]*)?$`)
+
+// validVersionString reports whether versionString can be parsed as a version by parseTag.
+func validVersionString(versionString string) bool {
+	return tagRe.MatchString(versionString)
+}
 
-	loop := true
-	releases := make([]*github.RepositoryRelease, 0)
+// parseTag parses a git tag into a semver.Version, normalizing conventions a strict parser would
+// reject: a leading "v", a missing patch component ("1.2" -> "1.2.0"), and "+build" metadata
+// ("1.2.3+incompatible"). Date-based pseudo-versions (e.g. "v0.0.0-20180628043050-7d04d0e2a0a1")
+// parse successfully as pre-releases, so callers that skip pre-releases drop them automatically.
+func parseTag(tag string) (*semver.Version, error) {
+	tag = strings.TrimPrefix(tag, "v")
 
-	for loop {
-		releasesPerPage, resp, err := client.Repositories.ListReleases(ctx, repoInput.Owner, repoInput.Repo, opt)
-		if err != nil {
-			//TODO: Add retry?
-			return releases, &resp.Rate, err
-		}
+	m := tagRe.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, fmt.Errorf("tag %q is not a valid version", tag)
+	}
 
-		releases = append(releases, releasesPerPage...)
-		loop = resp.NextPage > 0
-		opt.Page++
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
 	}
 
-	return releases, nil, nil
+	return semver.New(fmt.Sprintf("%s.%s.%s%s%s", m[1], m[2], patch, m[4], m[5])), nil
 }
 
-// Validate version string using regexp
-func validVersionString(versionString string) bool {
-	reVersion := regexp.MustCompile(`^\d{1,}.\d{1,}.\d{1,}$`)
-	reVersionWithPreRelease := regexp.MustCompile(`^\d{1,}.\d{1,}.\d{1,}-[0-9A-Za-z-]*(.[0-9A-Za-z-]*)*$`)
+var (
+	concurrencyFlag = flag.Int("concurrency", 4, "number of repos to fetch concurrently")
+	formatFlag      = flag.String("format", "text", "output format: text, json, or csv")
+	outputFlag      = flag.String("output", "", "file to write output to (default: stdout)")
+	predictFlag     = flag.Bool("predict", false, "also predict upcoming minor/patch versions per repo")
 
-	return reVersion.MatchString(versionString) || reVersionWithPreRelease.MatchString(versionString)
-}
+	predictReleasesFlag = flag.Int("predict-releases", 5, "max number of synthetic versions to predict per repo (requires -predict)")
+	predictMajorFlag    = flag.Int("predict-major", 0, "number of new major lines to predict per repo (requires -predict)")
+	predictMinorFlag    = flag.Int("predict-minor", 3, "number of new minor lines to predict on the highest observed major (requires -predict)")
+	predictPatchFlag    = flag.Int("predict-patch", 2, "number of further patches to predict on the current highest minor (requires -predict)")
+)
 
 // Here we implement the basics of communicating with github through the library as well as printing the version
 // You will need to implement LatestVersions function as well as make this application support the file format outlined in the README
 // Please use the format defined by the fmt.Printf line at the bottom, as we will define a passing coding challenge as one that outputs
 // the correct information, including this line
 func main() {
+	flag.Parse()
 
-	if len(os.Args) < 2 {
+	args := flag.Args()
+	if len(args) < 1 {
 		log.Fatal("Ensure that file path argument is provided.")
 	}
 
-	path := os.Args[1]
-	repos, err := readInputFromFile(path)
+	repos, err := readInputFromFile(args[0])
 	if err != nil {
 		log.Fatalf("Error occurred when reading input from file. Details: %v", err)
 	}
 
-	client := github.NewClient(nil)
-
-	for _, repoInput := range repos {
-		releases, rate, err := getReleasesForRepoFromGithub(client, &repoInput)
-		if err != nil {
-			if rate != nil && rate.Remaining == 0 {
-				log.Fatalf("Reached Github rate limit for unauthorized requests. Details: %v.", err)
-			}
-
-			log.Printf("Failed to retrieve all releases for %s/%s. Details: %v.", repoInput.Owner,
-				repoInput.Repo, err)
-			// TODO: Is it better to stop here? Or move on to the next repo?
-			continue
-
+	ctx := context.Background()
+	client := newGithubClient(ctx)
+
+	var pad *Padding
+	if *predictFlag {
+		pad = &Padding{
+			Releases: *predictReleasesFlag,
+			Major:    *predictMajorFlag,
+			Minor:    *predictMinorFlag,
+			Patch:    *predictPatchFlag,
 		}
+	}
 
-		allReleases := make([]*semver.Version, len(releases))
-		for i, release := range releases {
-			versionString := *release.TagName
-			if versionString[0] == 'v' {
-				versionString = versionString[1:]
-			}
-
-			// invalid version strings will be ignored else they will cause semver to panic
-			// this will cause the allReleases array to have nil values because its length is equal to the
-			// releases response. We can use a slice to solve this but an array (with nil checking in swap function)
-			// is the more efficient choice
-			if validVersionString(versionString) {
-				allReleases[i] = semver.New(versionString)
-			}
+	results := fetchAllReleases(ctx, client, repos, *concurrencyFlag, pad)
 
+	out := io.Writer(os.Stdout)
+	if *outputFlag != "" {
+		file, err := os.Create(*outputFlag)
+		if err != nil {
+			log.Fatalf("Failed to open output file %s. Details: %v", *outputFlag, err)
 		}
-
-		versionSlice := LatestVersions(allReleases, repoInput.MinVersion)
-		fmt.Printf("latest versions of %s/%s: %s\n", repoInput.Owner, repoInput.Repo, versionSlice)
+		defer file.Close()
+		out = file
 	}
 
+	if err := writeResults(out, *formatFlag, results); err != nil {
+		log.Fatalf("Failed to write output. Details: %v", err)
+	}
 }
@@ -23,49 +23,70 @@ func versionToStringSlice(versionSlice []*semver.Version) []string {
 	return stringSlice
 }
 
+func mustParseConstraint(t *testing.T, expr string) *Constraint {
+	t.Helper()
+	c, err := ParseConstraint(expr)
+	if err != nil {
+		t.Fatalf("failed to parse constraint %q: %v", expr, err)
+	}
+	return c
+}
+
 func TestLatestVersions(t *testing.T) {
 	testCases := []struct {
 		versionSlice   []string
 		expectedResult []string
-		minVersion     *semver.Version
+		constraintExpr string
 	}{
 		{
 			versionSlice:   []string{"1.8.11", "1.9.6", "1.10.1", "1.9.5", "1.8.10", "1.10.0", "1.7.14", "1.8.9", "1.9.5"},
 			expectedResult: []string{"1.10.1", "1.9.6", "1.8.11"},
-			minVersion:     semver.New("1.8.0"),
+			constraintExpr: ">=1.8.0",
 		},
 		{
 			versionSlice:   []string{"1.8.11", "1.9.6", "1.10.1", "1.9.5", "1.8.10", "1.10.0", "1.7.14", "1.8.9", "1.9.5"},
 			expectedResult: []string{"1.10.1", "1.9.6"},
-			minVersion:     semver.New("1.8.12"),
+			constraintExpr: ">=1.8.12",
 		},
 		{
 			versionSlice:   []string{"1.10.1", "1.9.5", "1.8.10", "1.10.0", "1.7.14", "1.8.9", "1.9.5"},
 			expectedResult: []string{"1.10.1"},
-			minVersion:     semver.New("1.10.0"),
+			constraintExpr: ">=1.10.0",
 		},
 		{
 			versionSlice:   []string{"2.2.1", "2.2.0"},
 			expectedResult: []string{"2.2.1"},
-			minVersion:     semver.New("2.2.1"),
+			constraintExpr: ">=2.2.1",
 		},
 		// Handle abnormal case where there are no releases after the min version
 		{
 			versionSlice:   []string{"2.2.6", "2.2.2", "2.4.8"},
 			expectedResult: []string{},
-			minVersion:     semver.New("2.6.1"),
+			constraintExpr: ">=2.6.1",
 		},
 		// Handle abnormal case where the input does not contain any versions
 		{
 			versionSlice:   []string{},
 			expectedResult: []string{},
-			minVersion:     semver.New("2.6.1"),
+			constraintExpr: ">=2.6.1",
+		},
+		// Only track the 1.x line, skipping the known-broken 1.9 minor
+		{
+			versionSlice:   []string{"1.8.11", "1.9.6", "1.10.1", "2.0.0", "1.9.0"},
+			expectedResult: []string{"1.10.1", "1.8.11"},
+			constraintExpr: ">=1.8.0 <1.9.0 || >=1.10.0 <2.0.0",
+		},
+		// Tilde range restricts to a single minor line
+		{
+			versionSlice:   []string{"1.8.11", "1.8.9", "1.9.0"},
+			expectedResult: []string{"1.8.11"},
+			constraintExpr: "~1.8.9",
 		},
-		// Implement more relevant test cases here, if you can think of any
 	}
 
-	test := func(versionData []string, expectedResult []string, minVersion *semver.Version) {
-		stringSlice := versionToStringSlice(LatestVersions(stringToVersionSlice(versionData), minVersion))
+	test := func(versionData []string, expectedResult []string, constraintExpr string) {
+		constraint := mustParseConstraint(t, constraintExpr)
+		stringSlice := versionToStringSlice(LatestVersions(stringToVersionSlice(versionData), constraint))
 		for i, versionString := range stringSlice {
 			if versionString != expectedResult[i] {
 				t.Errorf("Received %s, expected %s", stringSlice, expectedResult)
@@ -75,7 +96,7 @@ func TestLatestVersions(t *testing.T) {
 	}
 
 	for _, testValues := range testCases {
-		test(testValues.versionSlice, testValues.expectedResult, testValues.minVersion)
+		test(testValues.versionSlice, testValues.expectedResult, testValues.constraintExpr)
 	}
 }
 
@@ -92,6 +113,10 @@ func TestValidVersionString(t *testing.T)  {
 		{"1.2.1-alpha.1", true},
 		{"1.2.3-alpha.10.beta.0+build.unicorn.rainbow", true},
 		{"0.2.1", true},
+		{"1.2", true},
+		{"1.2.3+incompatible", true},
+		{"1.2.3+build.42", true},
+		{"0.0.0-20180628043050-7d04d0e2a0a1", true},
 		{"hello", false},
 		{"0.23.12.3", false},
 		{"", false},
@@ -102,4 +127,33 @@ func TestValidVersionString(t *testing.T)  {
 		assert.Equal(t, vs.ExpectedValidity, validVersionString(vs.VersionString),
 			"Tested version string: %s", vs.VersionString)
 	}
+}
+
+func TestParseTag(t *testing.T) {
+	validCases := []struct {
+		tag      string
+		expected string
+	}{
+		{"v1.2.3", "1.2.3"},
+		{"1.2.3", "1.2.3"},
+		{"v1.2", "1.2.0"},
+		{"1.2", "1.2.0"},
+		{"v1.2.3+incompatible", "1.2.3+incompatible"},
+		{"v1.2.3+build.42", "1.2.3+build.42"},
+		{"v0.0.0-20180628043050-7d04d0e2a0a1", "0.0.0-20180628043050-7d04d0e2a0a1"},
+	}
+
+	for _, vc := range validCases {
+		v, err := parseTag(vc.tag)
+		assert.NoError(t, err, "Tested tag: %s", vc.tag)
+		if err == nil {
+			assert.Equal(t, vc.expected, v.String(), "Tested tag: %s", vc.tag)
+		}
+	}
+
+	invalidTags := []string{"hello", "v0.23.12.3", ""}
+	for _, tag := range invalidTags {
+		_, err := parseTag(tag)
+		assert.Error(t, err, "Tested tag: %s", tag)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// jsonResult is the structured representation of a repoResult emitted by the json output format.
+type jsonResult struct {
+	Owner             string   `json:"owner"`
+	Repo              string   `json:"repo"`
+	MinVersion        string   `json:"min_version"`
+	LatestVersions    []string `json:"latest_versions"`
+	PredictedVersions []string `json:"predicted_versions,omitempty"`
+}
+
+// writeResults renders results in the requested format to out. "text" preserves the original
+// line-per-repo format verbatim; "json" and "csv" emit structured output for piping into other
+// tools. Repos that failed to fetch are logged and omitted from the data output.
+func writeResults(out io.Writer, format string, results []repoResult) error {
+	switch format {
+	case "text":
+		return writeResultsText(out, results)
+	case "json":
+		return writeResultsJSON(out, results)
+	case "csv":
+		return writeResultsCSV(out, results)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func writeResultsText(out io.Writer, results []repoResult) error {
+	for _, r := range results {
+		if r.err != nil {
+			log.Printf("Failed to retrieve all releases for %s/%s. Details: %v.", r.owner, r.repo, r.err)
+			continue
+		}
+
+		if _, err := fmt.Fprintf(out, "latest versions of %s/%s: %s\n", r.owner, r.repo, r.latestVersions); err != nil {
+			return err
+		}
+
+		if r.predictedVersions != nil {
+			if _, err := fmt.Fprintf(out, "predicted versions of %s/%s: %s\n", r.owner, r.repo, r.predictedVersions); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeResultsJSON(out io.Writer, results []repoResult) error {
+	jsonResults := make([]jsonResult, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			log.Printf("Failed to retrieve all releases for %s/%s. Details: %v.", r.owner, r.repo, r.err)
+			continue
+		}
+
+		jsonResults = append(jsonResults, jsonResult{
+			Owner:             r.owner,
+			Repo:              r.repo,
+			MinVersion:        r.minVersion,
+			LatestVersions:    versionsToStrings(r.latestVersions),
+			PredictedVersions: versionsToStrings(r.predictedVersions),
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonResults)
+}
+
+func writeResultsCSV(out io.Writer, results []repoResult) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"owner", "repo", "min_version", "latest_versions", "predicted_versions"}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.err != nil {
+			log.Printf("Failed to retrieve all releases for %s/%s. Details: %v.", r.owner, r.repo, r.err)
+			continue
+		}
+
+		record := []string{
+			r.owner,
+			r.repo,
+			r.minVersion,
+			strings.Join(versionsToStrings(r.latestVersions), ";"),
+			strings.Join(versionsToStrings(r.predictedVersions), ";"),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func versionsToStrings(versions []*semver.Version) []string {
+	strs := make([]string, len(versions))
+	for i, v := range versions {
+		strs[i] = v.String()
+	}
+	return strs
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func sampleResults() []repoResult {
+	return []repoResult{
+		{
+			owner:          "coreos",
+			repo:           "etcd",
+			minVersion:     ">=3.0.0",
+			latestVersions: stringToVersionSlice([]string{"3.5.1", "3.4.9"}),
+		},
+		{
+			owner: "broken",
+			repo:  "repo",
+			err:   errors.New("not found"),
+		},
+	}
+}
+
+func TestWriteResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, "text", sampleResults()); err != nil {
+		t.Fatalf("writeResults returned an error: %v", err)
+	}
+
+	got := buf.String()
+	want := "latest versions of coreos/etcd: [3.5.1 3.4.9]\n"
+	if got != want {
+		t.Errorf("writeResults(text) = %q, want %q", got, want)
+	}
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, "json", sampleResults()); err != nil {
+		t.Fatalf("writeResults returned an error: %v", err)
+	}
+
+	var decoded []jsonResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode json output: %v (output: %s)", err, buf.String())
+	}
+
+	if len(decoded) != 1 {
+		t.Fatalf("got %d json results, expected 1 (the failed repo should be omitted)", len(decoded))
+	}
+
+	if decoded[0].Owner != "coreos" || decoded[0].Repo != "etcd" || decoded[0].MinVersion != ">=3.0.0" {
+		t.Errorf("unexpected json result: %+v", decoded[0])
+	}
+	if len(decoded[0].LatestVersions) != 2 || decoded[0].LatestVersions[0] != "3.5.1" {
+		t.Errorf("unexpected latest_versions in json result: %v", decoded[0].LatestVersions)
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, "csv", sampleResults()); err != nil {
+		t.Fatalf("writeResults returned an error: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv output: %v (output: %s)", err, buf.String())
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d csv rows (including header), expected 2", len(records))
+	}
+	if records[0][0] != "owner" {
+		t.Errorf("unexpected csv header: %v", records[0])
+	}
+
+	row := records[1]
+	if row[0] != "coreos" || row[1] != "etcd" || row[2] != ">=3.0.0" || row[3] != "3.5.1;3.4.9" {
+		t.Errorf("unexpected csv row: %v", row)
+	}
+}
+
+func TestWriteResultsUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, "xml", sampleResults()); err == nil {
+		t.Error("expected an error for an unknown output format, got none")
+	}
+}
+
+func TestVersionsToStrings(t *testing.T) {
+	versions := stringToVersionSlice([]string{"1.0.0", "2.0.0"})
+	got := versionsToStrings(versions)
+	want := []string{"1.0.0", "2.0.0"}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("versionsToStrings(%v)[%d] = %q, want %q", versions, i, got[i], want[i])
+		}
+	}
+}
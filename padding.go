@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Padding configures how many synthetic future versions PredictedVersions should generate: up to
+// Patch further patches on the current highest minor, up to Minor new minor lines on the highest
+// major, and up to Major new major lines, capped at Releases total additions.
+type Padding struct {
+	Releases int
+	Major    int
+	Minor    int
+	Patch    int
+}
+
+// PredictedVersions predicts not-yet-released versions from the observed non-prerelease versions, so
+// that alerting/config can be preloaded for upcoming releases before they exist. Starting from the
+// highest observed version, it extrapolates forward in order of smallest to largest change: further
+// patches on the current highest minor, then new minor lines on the current highest major, then new
+// major lines, stopping once pad.Releases synthetic versions have been added.
+func PredictedVersions(releases []*semver.Version, pad Padding) []*semver.Version {
+	observed := nonPrereleaseVersions(releases)
+	if len(observed) == 0 {
+		return nil
+	}
+
+	sort.Sort(ByMajorMinorPatch(observed))
+	highest := observed[0]
+
+	predicted := make([]*semver.Version, 0, pad.Releases)
+
+	patch := highest.Patch
+	for i := 0; i < pad.Patch && len(predicted) < pad.Releases; i++ {
+		patch++
+		predicted = append(predicted, &semver.Version{Major: highest.Major, Minor: highest.Minor, Patch: patch})
+	}
+
+	minor := highest.Minor
+	for i := 0; i < pad.Minor && len(predicted) < pad.Releases; i++ {
+		minor++
+		predicted = append(predicted, &semver.Version{Major: highest.Major, Minor: minor, Patch: 0})
+	}
+
+	major := highest.Major
+	for i := 0; i < pad.Major && len(predicted) < pad.Releases; i++ {
+		major++
+		predicted = append(predicted, &semver.Version{Major: major, Minor: 0, Patch: 0})
+	}
+
+	sort.Sort(ByMajorMinorPatch(predicted))
+
+	return predicted
+}
+
+func nonPrereleaseVersions(versions []*semver.Version) []*semver.Version {
+	result := make([]*semver.Version, 0, len(versions))
+	for _, v := range versions {
+		if v != nil && v.PreRelease == "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
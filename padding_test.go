@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func TestPredictedVersionsNeverRegressesOrCrossesMajorCeiling(t *testing.T) {
+	testCases := []struct {
+		name    string
+		history []string
+		pad     Padding
+	}{
+		{
+			name:    "patch and minor padding",
+			history: []string{"1.8.11", "1.9.6", "1.10.1", "1.9.5", "1.8.10"},
+			pad:     Padding{Releases: 5, Major: 0, Minor: 3, Patch: 2},
+		},
+		{
+			name:    "patch only, releases cap reached early",
+			history: []string{"2.2.0", "2.2.1"},
+			pad:     Padding{Releases: 1, Major: 0, Minor: 3, Patch: 3},
+		},
+		{
+			name:    "no padding requested",
+			history: []string{"3.0.0"},
+			pad:     Padding{Releases: 0, Major: 0, Minor: 0, Patch: 0},
+		},
+		{
+			name:    "major padding predicts new major lines",
+			history: []string{"4.1.2"},
+			pad:     Padding{Releases: 4, Major: 2, Minor: 0, Patch: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			observed := stringToVersionSlice(tc.history)
+			highest := observed[0]
+			for _, v := range observed {
+				if v.Major > highest.Major || (v.Major == highest.Major && v.Minor > highest.Minor) ||
+					(v.Major == highest.Major && v.Minor == highest.Minor && v.Patch > highest.Patch) {
+					highest = v
+				}
+			}
+
+			predicted := PredictedVersions(observed, tc.pad)
+
+			if len(predicted) > tc.pad.Releases {
+				t.Errorf("got %d predicted versions, expected at most %d", len(predicted), tc.pad.Releases)
+			}
+
+			for _, v := range predicted {
+				if compareVersions(v, highest) <= 0 {
+					t.Errorf("predicted version %s does not improve on highest observed version %s", v, highest)
+				}
+
+				if v.Major > highest.Major+int64(tc.pad.Major) {
+					t.Errorf("predicted version %s crosses the major ceiling of %d", v, highest.Major+int64(tc.pad.Major))
+				}
+			}
+		})
+	}
+}
+
+func TestPredictedVersionsEmptyInput(t *testing.T) {
+	if got := PredictedVersions(nil, Padding{Releases: 5, Minor: 2, Patch: 2}); got != nil {
+		t.Errorf("expected no predictions for empty input, got %v", got)
+	}
+}
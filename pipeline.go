@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/google/go-github/github"
+)
+
+// repoResult is the outcome of fetching and computing latest versions for a single repo. index
+// records its position in the original input so results can be reassembled in that order regardless
+// of which worker finished first.
+type repoResult struct {
+	index             int
+	owner             string
+	repo              string
+	minVersion        string
+	latestVersions    []*semver.Version
+	predictedVersions []*semver.Version
+	err               error
+}
+
+// fetchAllReleases fans the repos out across concurrency worker goroutines, each calling
+// getReleasesForRepoFromGithub and computing its latest versions, and returns the results in the
+// original input order. When pad is non-nil, each result also gets its predicted future versions.
+// A non-positive concurrency is treated as 1, since fewer than one worker would leave jobs with no
+// reader and block forever.
+func fetchAllReleases(ctx context.Context, client *github.Client, repos []Input, concurrency int, pad *Padding) []repoResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+	results := make([]repoResult, len(repos))
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetchRepoResult(ctx, client, i, &repos[i], pad)
+			}
+		}()
+	}
+
+	for i := range repos {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+// fetchRepoResult fetches releases for a single repo and reduces them to its latest matching
+// versions, recording any failure on the result instead of aborting the run.
+func fetchRepoResult(ctx context.Context, client *github.Client, index int, repoInput *Input, pad *Padding) repoResult {
+	result := repoResult{
+		index:      index,
+		owner:      repoInput.Owner,
+		repo:       repoInput.Repo,
+		minVersion: repoInput.Constraint.String(),
+	}
+
+	releases, rate, err := getReleasesForRepoFromGithub(ctx, client, repoInput)
+	if err != nil {
+		if rate != nil && rate.Remaining == 0 {
+			result.err = fmt.Errorf("reached Github rate limit: %w", err)
+		} else {
+			result.err = err
+		}
+		return result
+	}
+
+	if rate != nil && rate.Remaining < RELEASES_PER_PAGE {
+		log.Printf("Github rate limit running low: %d/%d remaining, resets at %s.", rate.Remaining, rate.Limit, rate.Reset)
+	}
+
+	allReleases := make([]*semver.Version, len(releases))
+	for i, release := range releases {
+		if v, err := parseTag(*release.TagName); err == nil {
+			allReleases[i] = v
+		}
+	}
+
+	result.latestVersions = LatestVersions(allReleases, repoInput.Constraint)
+	if pad != nil {
+		result.predictedVersions = PredictedVersions(allReleases, *pad)
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// newTestGithubClient returns a github.Client pointed at a test server that serves tag releases for
+// "owner/name" from tags, one release per page, and 404s for anything else.
+func newTestGithubClient(t *testing.T, tags map[string][]string) (*github.Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for repo, repoTags := range tags {
+			if r.URL.Path == fmt.Sprintf("/repos/%s/releases", repo) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(releasesJSON(repoTags)))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	return client, server.Close
+}
+
+func releasesJSON(tags []string) string {
+	body := "["
+	for i, tag := range tags {
+		if i > 0 {
+			body += ","
+		}
+		body += fmt.Sprintf(`{"tag_name": %q}`, tag)
+	}
+	return body + "]"
+}
+
+func TestFetchAllReleasesPreservesInputOrder(t *testing.T) {
+	client, closeServer := newTestGithubClient(t, map[string][]string{
+		"alpha/one": {"v1.2.0", "v1.1.0"},
+		"beta/two":  {"v2.0.0"},
+		"gamma/3":   {"v0.9.0"},
+	})
+	defer closeServer()
+
+	repos := []Input{
+		{Owner: "alpha", Repo: "one", Constraint: mustParseConstraint(t, ">=0.0.0")},
+		{Owner: "beta", Repo: "two", Constraint: mustParseConstraint(t, ">=0.0.0")},
+		{Owner: "gamma", Repo: "3", Constraint: mustParseConstraint(t, ">=0.0.0")},
+	}
+
+	results := fetchAllReleases(context.Background(), client, repos, 8, nil)
+
+	if len(results) != len(repos) {
+		t.Fatalf("got %d results, expected %d", len(results), len(repos))
+	}
+
+	for i, r := range results {
+		if r.owner != repos[i].Owner || r.repo != repos[i].Repo {
+			t.Errorf("result %d is %s/%s, expected %s/%s in input order", i, r.owner, r.repo, repos[i].Owner, repos[i].Repo)
+		}
+		if r.err != nil {
+			t.Errorf("result %d (%s/%s): unexpected error: %v", i, r.owner, r.repo, r.err)
+		}
+	}
+
+	if len(results[0].latestVersions) != 2 ||
+		results[0].latestVersions[0].String() != "1.2.0" ||
+		results[0].latestVersions[1].String() != "1.1.0" {
+		t.Errorf("alpha/one latest versions = %v, expected [1.2.0 1.1.0]", results[0].latestVersions)
+	}
+}
+
+func TestFetchAllReleasesClampsNonPositiveConcurrency(t *testing.T) {
+	client, closeServer := newTestGithubClient(t, map[string][]string{"alpha/one": {"v1.0.0"}})
+	defer closeServer()
+
+	repos := []Input{{Owner: "alpha", Repo: "one", Constraint: mustParseConstraint(t, ">=0.0.0")}}
+
+	done := make(chan []repoResult, 1)
+	go func() {
+		done <- fetchAllReleases(context.Background(), client, repos, 0, nil)
+	}()
+
+	select {
+	case results := <-done:
+		if len(results) != 1 || results[0].err != nil {
+			t.Errorf("unexpected results for concurrency=0: %+v", results)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("fetchAllReleases(concurrency=0) did not return in time; it likely deadlocked")
+	}
+}